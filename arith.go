@@ -0,0 +1,129 @@
+// arith.go - FMA, Percent, Pow, and big.Rat/big.Float bridges for Currency
+//
+// (c) 2017, Sudhi Herle <sudhi@herle.net>
+//
+//
+// Licensing Terms: GPLv2
+//
+// If you need a commercial license for this work, please contact
+// the author.
+//
+// This software does not come with any express or implied
+// warranty; it is provided "as is". No claim  is made to its
+// suitability for any purpose.
+
+package currency
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// FMA returns x*y + z, rounded using the package's default rounding
+// mode (see SetDefaultRounding) in a single step. This avoids the
+// extra rounding error that Mul followed by Add would introduce for
+// compounding computations (e.g. interest accrual) where z itself is
+// the running total.
+func FMA(x, y, z *Currency) *Currency {
+	num := new(big.Int).Mul(&x.Int, &y.Int)
+	num.Add(num, new(big.Int).Mul(&z.Int, iBigMult))
+
+	neg := num.Sign() < 0
+	num.Abs(num)
+
+	q := quoRound(num, iBigMult, defaultRounding, neg)
+	if neg {
+		q.Neg(q)
+	}
+	return &Currency{Int: *q}
+}
+
+// Percent returns p * pct / 100, rounded using the package's default
+// rounding mode. 'pct' is expressed in percentage points - e.g.
+// pct=5 means "5 percent" - and sets p to the result.
+func (p *Currency) Percent(pct *Currency) *Currency {
+	num := new(big.Int).Mul(&p.Int, &pct.Int)
+	den := new(big.Int).Mul(iBigMult, big.NewInt(100))
+
+	neg := num.Sign() < 0
+	num.Abs(num)
+
+	q := quoRound(num, den, defaultRounding, neg)
+	if neg {
+		q.Neg(q)
+	}
+
+	p.Int.Set(q)
+	return p
+}
+
+// Pow raises 'p' to the n-th power, rounding every intermediate
+// multiplication using the package's default rounding mode, and sets
+// p to the result. Pow(0) sets p to 1 (one whole unit). A negative n
+// computes 1/(p**-n), as with Inv.
+func (p *Currency) Pow(n int) *Currency {
+	if n == 0 {
+		p.Int.Set(iBigMult)
+		return p
+	}
+
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+
+	base := new(big.Int).Set(&p.Int)
+	result := new(big.Int).Set(base)
+	for i := 1; i < n; i++ {
+		result = mulRound(result, base, defaultRounding)
+	}
+
+	if neg {
+		one := &Currency{Int: *iBigMult}
+		acc := &Currency{Int: *result}
+		inv := DivRound(one, acc, defaultRounding)
+		p.Int.Set(&inv.Int)
+		return p
+	}
+
+	p.Int.Set(result)
+	return p
+}
+
+// ToRat returns the exact value of 'p' as a big.Rat (p's atto-dollar
+// magnitude over iBigMult), with no rounding - unlike the string-based
+// accessors, this lets callers hand 'p' off to math/big for further,
+// lossless computation (e.g. compound interest, IRR).
+func (p *Currency) ToRat() *big.Rat {
+	return new(big.Rat).SetFrac(&p.Int, iBigMult)
+}
+
+// FromRat creates a Currency from a big.Rat, rounding to atto-dollar
+// precision using the package's default rounding mode (see
+// SetDefaultRounding).
+func FromRat(r *big.Rat) (*Currency, error) {
+	if r == nil {
+		return nil, fmt.Errorf("currency: nil Rat")
+	}
+
+	num := new(big.Int).Mul(r.Num(), iBigMult)
+	den := r.Denom()
+
+	neg := num.Sign() < 0
+	num.Abs(num)
+
+	q := quoRound(num, den, defaultRounding, neg)
+	if neg {
+		q.Neg(q)
+	}
+	return &Currency{Int: *q}, nil
+}
+
+// ToFloat returns the value of 'p' as a big.Float with the given
+// precision (in bits), for callers that need math/big's
+// floating-point operations (e.g. Sqrt).
+func (p *Currency) ToFloat(prec uint) *big.Float {
+	f := new(big.Float).SetPrec(prec).SetInt(&p.Int)
+	m := new(big.Float).SetPrec(prec).SetInt(iBigMult)
+	return f.Quo(f, m)
+}
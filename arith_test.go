@@ -0,0 +1,123 @@
+package currency_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"currency"
+)
+
+func cloneCur(c *currency.Currency) *currency.Currency {
+	d := currency.New()
+	d.Int.Set(&c.Int)
+	return d
+}
+
+func Test_FMA(t *testing.T) {
+	x, err := currency.NewFromString("2.5")
+	assert(err == nil, t)
+	y, err := currency.NewFromString("4")
+	assert(err == nil, t)
+	z, err := currency.NewFromString("1")
+	assert(err == nil, t)
+
+	r := currency.FMA(x, y, z)
+	assert(r.ExactString() == "11", t)
+}
+
+func Test_Percent(t *testing.T) {
+	p, err := currency.NewFromString("200")
+	assert(err == nil, t)
+	pct, err := currency.NewFromString("5")
+	assert(err == nil, t)
+
+	p.Percent(pct)
+	assert(p.ExactString() == "10", t)
+}
+
+func Test_Pow(t *testing.T) {
+	p, err := currency.NewFromString("2")
+	assert(err == nil, t)
+	p.Pow(10)
+	assert(p.ExactString() == "1024", t)
+
+	q, err := currency.NewFromString("2")
+	assert(err == nil, t)
+	q.Pow(-1)
+	assert(q.ExactString() == "0.5", t)
+
+	z, err := currency.NewFromString("7")
+	assert(err == nil, t)
+	z.Pow(0)
+	assert(z.ExactString() == "1", t)
+}
+
+func Test_ToRatFromRat(t *testing.T) {
+	c, err := currency.NewFromString("123.456789012345678")
+	assert(err == nil, t)
+
+	r := c.ToRat()
+	d, err := currency.FromRat(r)
+	assert(err == nil, t)
+	assert(d.ExactString() == c.ExactString(), t)
+}
+
+func Test_ToFloat(t *testing.T) {
+	c, err := currency.NewFromString("0.5")
+	assert(err == nil, t)
+
+	f := c.ToFloat(64)
+	got, _ := f.Float64()
+	assert(got == 0.5, t)
+}
+
+// oneAtto is the smallest representable Currency step (1e-18), used
+// below as the rounding tolerance when cross-validating Mul/Div/FMA
+// against exact big.Rat arithmetic.
+var oneAtto = big.NewRat(1, attoScale)
+
+func closeEnough(got, want *big.Rat) bool {
+	diff := new(big.Rat).Sub(got, want)
+	return new(big.Rat).Abs(diff).Cmp(oneAtto) <= 0
+}
+
+// Test_FuzzAgainstRat cross-validates Add, Sub, Mul, Div and FMA
+// against exact math/big.Rat arithmetic over random atto-scaled
+// operands. Add and Sub are exact; Mul, Div and FMA are allowed to be
+// off by at most one atto unit, the rounding error ToZero introduces.
+func Test_FuzzAgainstRat(t *testing.T) {
+	currency.SetDefaultRounding(currency.ToZero)
+	defer currency.SetDefaultRounding(currency.ToZero)
+
+	rnd := rand.New(rand.NewSource(1))
+	const maxAtto = attoScale * 5 // keep well clear of int64's ~9.22e18 ceiling
+
+	for i := 0; i < 200; i++ {
+		a := mkraw(rnd.Int63n(maxAtto) - maxAtto/2)
+		b := mkraw(rnd.Int63n(maxAtto) - maxAtto/2)
+		c := mkraw(rnd.Int63n(maxAtto) - maxAtto/2)
+
+		ra, rb, rc := a.ToRat(), b.ToRat(), c.ToRat()
+
+		sum, err := cloneCur(a).Add(cloneCur(b))
+		assert(err == nil, t)
+		assert(sum.ToRat().Cmp(new(big.Rat).Add(ra, rb)) == 0, t)
+
+		diff, err := cloneCur(a).Sub(cloneCur(b))
+		assert(err == nil, t)
+		assert(diff.ToRat().Cmp(new(big.Rat).Sub(ra, rb)) == 0, t)
+
+		prod := cloneCur(a).Mul(cloneCur(b))
+		assert(closeEnough(prod.ToRat(), new(big.Rat).Mul(ra, rb)), t)
+
+		if !b.IsZero() {
+			quot := cloneCur(a).Div(cloneCur(b))
+			assert(closeEnough(quot.ToRat(), new(big.Rat).Quo(ra, rb)), t)
+		}
+
+		fma := currency.FMA(cloneCur(a), cloneCur(b), cloneCur(c))
+		want := new(big.Rat).Add(new(big.Rat).Mul(ra, rb), rc)
+		assert(closeEnough(fma.ToRat(), want), t)
+	}
+}
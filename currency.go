@@ -18,17 +18,27 @@
 // output conversion to string uses the full (18 decimal digit)
 // precision. Output string representation is not rounded - but
 // truncated.
+//
+// A Currency value may optionally carry a Unit identifying the ISO
+// 4217 currency (or other unit of account, e.g. a cryptocurrency) it
+// is denominated in; see Unit and RegisterUnit. Values with no Unit
+// retain the package's original, single-currency behavior.
 package currency
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"strings"
 )
 
-// A currency is represented as atto dollars (18 digits of precision)
+// A currency is represented as atto dollars (18 digits of precision).
+// Unit, if non-nil, identifies the currency (or other unit of
+// account) this value is denominated in; a nil Unit preserves the
+// package's original, unit-less behavior.
 type Currency struct {
 	big.Int
+	Unit *Unit
 }
 
 // Atto exponent, multiplication factor and padding string
@@ -69,45 +79,98 @@ func New() *Currency {
 	return &Currency{}
 }
 
-// Make a new Currency instance with input string 's' and output
-// precision of 'oprec'. If 'oprec' is more than Atto Dollars, it is
-// clamped at Atto Dollars (18). If it is less than or equal to
-// zero, it is clamped at 6.
+// Make a new Currency instance with input string 's'. 's' may
+// optionally carry a registered unit code, either before or after the
+// number, e.g. "USD 12.34" or "12.34 USD" - in which case the
+// returned Currency's Unit is set accordingly. The number itself may
+// carry a trailing SI magnitude suffix (K, M, B, T, Q), e.g. "1.23K"
+// for 1230. Without a unit code or suffix, 's' is parsed exactly as
+// before and the result carries a nil Unit.
 func NewFromString(s string) (*Currency, error) {
 	p := &Currency{}
 
+	s = strings.TrimSpace(s)
 	if len(s) > 0 {
-		err := parse(&p.Int, s)
+		num, unit, err := splitUnit(s)
 		if err != nil {
 			return nil, err
 		}
+
+		num, exp := stripHumanSuffix(num)
+		if err := parse(&p.Int, num); err != nil {
+			return nil, err
+		}
+		if exp > 0 {
+			p.Int.Mul(&p.Int, pow10Big(exp))
+		}
+		p.Unit = unit
 	}
 
 	return p, nil
 }
 
-// Convert 'p' to a string - bounded by output precision
-// We just shift 12 digits off the left and print it.
+// splitUnit extracts an optional leading or trailing unit code from
+// 's' and returns the remaining numeric text together with the
+// matching registered Unit (nil if 's' carries no unit code).
+func splitUnit(s string) (string, *Unit, error) {
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 1:
+		return fields[0], nil, nil
+
+	case 2:
+		if u := LookupUnit(fields[0]); u != nil {
+			return fields[1], u, nil
+		}
+		if u := LookupUnit(fields[1]); u != nil {
+			return fields[0], u, nil
+		}
+		return "", nil, fmt.Errorf("unknown unit in %q", s)
+
+	default:
+		return "", nil, fmt.Errorf("malformed decimal %s", s)
+	}
+}
+
+// Convert 'p' to a string. If 'p' carries a Unit, the value is
+// formatted at the unit's native scale and grouped using the
+// package's default locale (see SetDefaultLocale); otherwise it is
+// shown at the full (18 decimal digit) atto-dollar precision, exactly
+// as before.
 func (p Currency) String() string {
-	return stringify(&p.Int, eExp)
+	if p.Unit == nil {
+		return stringify(&p.Int, eExp)
+	}
+	return p.StringLocale(defaultLocale)
 }
 
 // Show 'p' to a string bounded by output precision 'oprec'
 // If 'oprec' is more than the atto-dollar resolution, it is clamped
-// at 12.
+// at 18. Digits beyond 'oprec' are rounded using the package's
+// default rounding mode (see SetDefaultRounding); by default this is
+// ToZero, i.e., truncation - the historical behavior of this function.
 func (p *Currency) StringFixed(oprec int) string {
 	if oprec > eExp || oprec <= 0 {
 		oprec = eExp
 	}
 
-	return stringify(&p.Int, oprec)
+	r := roundAtPrec(&p.Int, oprec, defaultRounding)
+	return stringify(r, oprec)
 }
 
 // stringify atto-dollars in 'b'
 func stringify(b *big.Int, oprec int) string {
 	var m, x string
 
+	// Strip the sign before measuring 's' against eExp - otherwise a
+	// negative value whose magnitude is exactly eExp digits long (i.e.
+	// 0.1 <= |b| < 1.0, e.g. "-0.45") would have its '-' counted as a
+	// magnitude digit and be misrouted into the wrong branch below.
+	neg := b.Sign() < 0
 	s := b.String()
+	if neg {
+		s = s[1:]
+	}
 
 	// Not enough atto dollars
 	if len(s) <= eExp {
@@ -126,31 +189,53 @@ func stringify(b *big.Int, oprec int) string {
 		x = x[:oprec]
 	}
 
-	return fmt.Sprintf("%s.%s", m, x)
+	sign := ""
+	if neg {
+		sign = "-"
+	}
+
+	if oprec == 0 {
+		return sign + m
+	}
+
+	return fmt.Sprintf("%s%s.%s", sign, m, x)
 }
 
-// Add 'x' to 'p'
-func (p *Currency) Add(x *Currency) *Currency {
+// Add 'x' to 'p'. It is an error to add two Currency values that
+// carry different, non-nil units.
+func (p *Currency) Add(x *Currency) (*Currency, error) {
+	if err := checkUnit(p, x); err != nil {
+		return nil, err
+	}
 	p.Int.Add(&p.Int, &x.Int)
-	return p
+	p.Unit = chooseUnit(p.Unit, x.Unit)
+	return p, nil
 }
 
-// Subtract 'x' from 'p'
-func (p *Currency) Sub(x *Currency) *Currency {
+// Subtract 'x' from 'p'. It is an error to subtract two Currency
+// values that carry different, non-nil units.
+func (p *Currency) Sub(x *Currency) (*Currency, error) {
+	if err := checkUnit(p, x); err != nil {
+		return nil, err
+	}
 	p.Int.Sub(&p.Int, &x.Int)
-	return p
+	p.Unit = chooseUnit(p.Unit, x.Unit)
+	return p, nil
 }
 
-// Multiply 'p' with 'x'
+// Multiply 'p' with 'x'. Both operands are atto-scaled, so the raw
+// product is rescaled back down by one factor of iBigMult, rounding
+// using the package's default rounding mode (see SetDefaultRounding).
 func (p *Currency) Mul(x *Currency) *Currency {
-	p.Int.Mul(&p.Int, &x.Int)
+	p.Int.Set(mulRound(&p.Int, &x.Int, defaultRounding))
 	return p
 }
 
-// Divide 'p' by 'x' and return the dividend
+// Divide 'p' by 'x', set p to the quotient and return 'p'. The quotient is rounded
+// using the package's default rounding mode (see SetDefaultRounding);
+// use DivRound to specify a rounding mode explicitly.
 func (p *Currency) Div(x *Currency) *Currency {
-	p.Int.Quo(&p.Int, &x.Int)
-	return p
+	return p.DivRound(x, defaultRounding)
 }
 
 // Divide 'p' by 'x', and set p to the quotient and return 'p' and
@@ -172,41 +257,52 @@ func (p *Currency) IsZero() bool {
 	return 0 == p.Cmp(zero)
 }
 
-// Return true if 'p' is equal to 'x', false otherwise
-func (p *Currency) Eq(x *Currency) bool {
-	return 0 == p.Int.Cmp(&x.Int)
+// Return true if 'p' is equal to 'x', false otherwise. It is an error
+// to compare two Currency values that carry different, non-nil units.
+func (p *Currency) Eq(x *Currency) (bool, error) {
+	if err := checkUnit(p, x); err != nil {
+		return false, err
+	}
+	return 0 == p.Int.Cmp(&x.Int), nil
 }
 
-// Return a+b
-func Add(a, b *Currency) *Currency {
+// Return a+b. It is an error to add two Currency values that carry
+// different, non-nil units.
+func Add(a, b *Currency) (*Currency, error) {
+	if err := checkUnit(a, b); err != nil {
+		return nil, err
+	}
+
 	var z big.Int
 
 	z.Add(&a.Int, &b.Int)
-	return &Currency{Int: z}
+	return &Currency{Int: z, Unit: chooseUnit(a.Unit, b.Unit)}, nil
 }
 
-// Return a-b
-func Sub(a, b *Currency) *Currency {
+// Return a-b. It is an error to subtract two Currency values that
+// carry different, non-nil units.
+func Sub(a, b *Currency) (*Currency, error) {
+	if err := checkUnit(a, b); err != nil {
+		return nil, err
+	}
+
 	var z big.Int
 
 	z.Sub(&a.Int, &b.Int)
-	return &Currency{Int: z}
+	return &Currency{Int: z, Unit: chooseUnit(a.Unit, b.Unit)}, nil
 }
 
-// Return a*b
+// Return a*b, rounded using the package's default rounding mode (see
+// SetDefaultRounding).
 func Mul(a, b *Currency) *Currency {
-	var z big.Int
-
-	z.Mul(&a.Int, &b.Int)
-	return &Currency{Int: z}
+	return &Currency{Int: *mulRound(&a.Int, &b.Int, defaultRounding)}
 }
 
-// Return a/b
+// Return a/b, rounded using the package's default rounding mode (see
+// SetDefaultRounding); use DivRound to specify a rounding mode
+// explicitly.
 func Div(a, b *Currency) *Currency {
-	var z big.Int
-
-	z.Quo(&a.Int, &b.Int)
-	return &Currency{Int: z}
+	return DivRound(a, b, defaultRounding)
 }
 
 // Do Euclidean division of a by b, return the quotient and
@@ -219,38 +315,75 @@ func DivMod(a, b *Currency) (*Currency, *Currency) {
 	return &Currency{Int: z}, &Currency{Int: r}
 }
 
-// Return 1/a
+// Return 1/a, rounded using the package's default rounding mode (see
+// SetDefaultRounding).
 func Inv(a *Currency) *Currency {
-	var z big.Int
+	one := &Currency{Int: *iBigMult}
+	return DivRound(one, a, defaultRounding)
+}
 
-	z.Quo(iBigMult, &a.Int)
-	return &Currency{Int: z}
+// Return true if a == b. It is an error to compare two Currency
+// values that carry different, non-nil units.
+func Eq(a, b *Currency) (bool, error) {
+	if err := checkUnit(a, b); err != nil {
+		return false, err
+	}
+	return 0 == a.Int.Cmp(&b.Int), nil
 }
 
-// Return true of a == b
-func Eq(a, b *Currency) bool {
-	return 0 == a.Int.Cmp(&b.Int)
+// Return -1, 0, +1 if a < b, a == b, a > b respectively. It is an
+// error to compare two Currency values that carry different, non-nil
+// units.
+func Cmp(a, b *Currency) (int, error) {
+	if err := checkUnit(a, b); err != nil {
+		return 0, err
+	}
+	return a.Int.Cmp(&b.Int), nil
 }
 
-// Return -1, 0, +1 if a < b, a == b, a > b respectively
-func Cmp(a, b *Currency) int {
-	return a.Int.Cmp(&b.Int)
+// jsonQuoted controls whether MarshalJSON quotes its output. It
+// defaults to true (emitting "123.45") since most JSON consumers
+// parse bare numeric tokens as float64 and silently lose precision.
+// Set it to false only for legacy consumers that depend on the old,
+// unquoted output.
+var jsonQuoted = true
+
+// SetJSONQuoted changes whether MarshalJSON quotes its output.
+func SetJSONQuoted(quoted bool) {
+	jsonQuoted = quoted
 }
 
-// Marshal 'p' to JSON
+// Marshal 'p' to JSON. By default this emits a quoted decimal string
+// (e.g. "123.45") using ExactString, since a bare JSON number token
+// loses precision through most (float64-based) JSON parsers. See
+// SetJSONQuoted to restore the historical, unquoted output.
 func (p *Currency) MarshalJSON() ([]byte, error) {
-	s := p.String()
-	return []byte(s), nil
+	s := p.ExactString()
+	if !jsonQuoted {
+		return []byte(s), nil
+	}
+	return json.Marshal(s)
 }
 
-// Unmarshal JSON to 'p'
+// Unmarshal JSON to 'p'. Accepts both the quoted ("123.45") and
+// historical unquoted (123.45) forms.
 func (p *Currency) UnmarshalJSON(txt []byte) error {
+	s := string(txt)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		var unquoted string
+		if err := json.Unmarshal(txt, &unquoted); err != nil {
+			return err
+		}
+		s = unquoted
+	}
 
-	return parse(&p.Int, string(txt))
+	return parse(&p.Int, s)
 }
 
 // Parse a valid string 's' into a atto-dollar big.Int
 func parse(p *big.Int, s string) error {
+	neg := strings.HasPrefix(s, "-")
+
 	v := strings.Split(s, ".")
 	var pre, post string
 
@@ -296,7 +429,15 @@ func parse(p *big.Int, s string) error {
 	}
 
 	p.Mul(p, iBigMult)
-	p.Add(p, f)
+
+	// 'pre' may have been "-0" (its sign lost once the integer part
+	// rounds to zero, e.g. "-0.45"), so the fraction is combined
+	// according to the sign of the original string, not of 'p' itself.
+	if neg {
+		p.Sub(p, f)
+	} else {
+		p.Add(p, f)
+	}
 	return nil
 }
 
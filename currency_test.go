@@ -53,6 +53,27 @@ func Test_fmt(t *testing.T) {
 	}
 }
 
+var negTests = [...]testcase{
+	{in: "-123.45", out: "-123.45"},
+	{in: "-0.45", out: "-0.45"},
+	{in: "-123", out: "-123"},
+}
+
+// Test_NegativeValueSymmetry guards against parse() combining the
+// fractional part with the wrong sign - e.g. "-123.45" silently
+// becoming "-122.55" - by asserting the exact output of
+// NewFromString, not just internal round-trip equality.
+func Test_NegativeValueSymmetry(t *testing.T) {
+	for _, tc := range negTests {
+		c, err := currency.NewFromString(tc.in)
+		assert(err == nil, t)
+
+		s := c.ExactString()
+		t.Logf("in=|%s| => |%s| (want |%s|)\n", tc.in, s, tc.out)
+		assert(s == tc.out, t)
+	}
+}
+
 func Test_json(t *testing.T) {
 	ii, err := currency.NewFromString("123.0005430123")
 	assert(err == nil, t)
@@ -66,7 +87,10 @@ func Test_json(t *testing.T) {
 
 	err = json.Unmarshal(m, &xx)
 	assert(err == nil, t)
-	assert(ii.Eq(&xx), t)
+
+	eq, err := ii.Eq(&xx)
+	assert(err == nil, t)
+	assert(eq, t)
 }
 
 func Benchmark_NewFromString(b *testing.B) {
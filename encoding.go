@@ -0,0 +1,150 @@
+// encoding.go - fmt.Formatter, encoding.Text/BinaryMarshaler for Currency
+//
+// (c) 2017, Sudhi Herle <sudhi@herle.net>
+//
+//
+// Licensing Terms: GPLv2
+//
+// If you need a commercial license for this work, please contact
+// the author.
+//
+// This software does not come with any express or implied
+// warranty; it is provided "as is". No claim  is made to its
+// suitability for any purpose.
+
+package currency
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// Format implements fmt.Formatter. Supported verbs:
+//
+//	%d  atto units (the raw, unscaled big.Int) - flags as per big.Int
+//	%f  fixed-point, honoring precision (e.g. "%.4f")
+//	%e  scientific notation, honoring precision (e.g. "%.2e")
+//	%s  same as String()
+//
+// The '+' flag forces a leading sign on %f/%e, and a width forces
+// padding, matching the conventions of big.Int and big.Float.
+func (p Currency) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'd':
+		p.Int.Format(s, verb)
+		return
+
+	case 's', 'v':
+		io.WriteString(s, p.pad(s, p.String()))
+		return
+
+	case 'f', 'F':
+		prec := 2
+		if n, ok := s.Precision(); ok {
+			prec = n
+		}
+		str := p.StringFixedRound(prec, defaultRounding)
+		if s.Flag('+') && p.Int.Sign() >= 0 {
+			str = "+" + str
+		}
+		io.WriteString(s, p.pad(s, str))
+		return
+
+	case 'e', 'E':
+		prec := 6
+		if n, ok := s.Precision(); ok {
+			prec = n
+		}
+
+		f, _, err := big.ParseFloat(p.ExactString(), 10, 256, big.ToNearestEven)
+		if err != nil {
+			fmt.Fprintf(s, "%%!%c(currency.Currency=%s)", verb, p.String())
+			return
+		}
+
+		format := byte('e')
+		if verb == 'E' {
+			format = 'E'
+		}
+		str := f.Text(format, prec)
+		if s.Flag('+') && f.Sign() >= 0 {
+			str = "+" + str
+		}
+		io.WriteString(s, p.pad(s, str))
+		return
+
+	default:
+		fmt.Fprintf(s, "%%!%c(currency.Currency=%s)", verb, p.String())
+	}
+}
+
+// pad applies 's's width (left-padding, or right-padding when the '-'
+// flag is set) to 'str'.
+func (p Currency) pad(s fmt.State, str string) string {
+	width, ok := s.Width()
+	if !ok || len(str) >= width {
+		return str
+	}
+
+	fill := strings.Repeat(" ", width-len(str))
+	if s.Flag('-') {
+		return str + fill
+	}
+	return fill + str
+}
+
+// MarshalText implements encoding.TextMarshaler, emitting the exact
+// decimal value (see ExactString), followed by the unit code if 'p'
+// carries a Unit.
+func (p Currency) MarshalText() ([]byte, error) {
+	s := p.ExactString()
+	if p.Unit != nil {
+		s = s + " " + p.Unit.Code
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Currency) UnmarshalText(text []byte) error {
+	c, err := NewFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*p = *c
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The wire format
+// is a single sign byte (0 for >= 0, 1 for negative) followed by the
+// big-endian bytes of the atto-unit magnitude - the same
+// representation big.Int.Bytes() produces - so it round-trips through
+// gob or a protobuf "bytes" field.
+func (p Currency) MarshalBinary() ([]byte, error) {
+	sign := byte(0)
+	if p.Int.Sign() < 0 {
+		sign = 1
+	}
+
+	mag := new(big.Int).Abs(&p.Int).Bytes()
+	buf := make([]byte, 1+len(mag))
+	buf[0] = sign
+	copy(buf[1:], mag)
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (p *Currency) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("currency: empty binary data")
+	}
+
+	var z big.Int
+	z.SetBytes(data[1:])
+	if data[0] == 1 {
+		z.Neg(&z)
+	}
+	p.Int = z
+	return nil
+}
@@ -0,0 +1,129 @@
+package currency_test
+
+import (
+	"fmt"
+	"testing"
+
+	"currency"
+)
+
+func Test_Format(t *testing.T) {
+	c, err := currency.NewFromString("1234.5")
+	assert(err == nil, t)
+
+	cases := []struct {
+		format string
+		want   string
+	}{
+		{"%.2f", "1234.50"},
+		{"%+.2f", "+1234.50"},
+		{"%s", c.String()},
+	}
+
+	for _, tc := range cases {
+		got := fmt.Sprintf(tc.format, c)
+		t.Logf("%s => |%s| (want |%s|)\n", tc.format, got, tc.want)
+		assert(got == tc.want, t)
+	}
+}
+
+func Test_TextMarshal(t *testing.T) {
+	for _, s := range []string{"123.45", "-50.25"} {
+		c, err := currency.NewFromString(s)
+		assert(err == nil, t)
+
+		b, err := c.MarshalText()
+		assert(err == nil, t)
+		assert(string(b) == s, t)
+
+		var d currency.Currency
+		assert(d.UnmarshalText(b) == nil, t)
+
+		eq, err := c.Eq(&d)
+		assert(err == nil, t)
+		assert(eq, t)
+
+		// Compare against the known-good value, not just c's own
+		// round-trip - c itself is only as good as UnmarshalText's
+		// underlying parse().
+		assert(d.ExactString() == s, t)
+	}
+}
+
+func Test_BinaryMarshal(t *testing.T) {
+	for _, s := range []string{"123.45", "-123.45", "0"} {
+		c, err := currency.NewFromString(s)
+		assert(err == nil, t)
+
+		b, err := c.MarshalBinary()
+		assert(err == nil, t)
+
+		var d currency.Currency
+		assert(d.UnmarshalBinary(b) == nil, t)
+
+		eq, err := c.Eq(&d)
+		assert(err == nil, t)
+		t.Logf("in=%s c=%s d=%s\n", s, c.ExactString(), d.ExactString())
+		assert(eq, t)
+
+		// Compare against the known-good value, not just c's own
+		// round-trip - c itself is only as good as NewFromString's
+		// underlying parse().
+		assert(c.ExactString() == s, t)
+		assert(d.ExactString() == s, t)
+	}
+}
+
+func Test_SQLValue(t *testing.T) {
+	c, err := currency.NewFromString("99.99")
+	assert(err == nil, t)
+
+	v, err := c.Value()
+	assert(err == nil, t)
+	assert(v == "99.99", t)
+
+	var d currency.Currency
+	assert(d.Scan([]byte("99.99")) == nil, t)
+
+	eq, err := c.Eq(&d)
+	assert(err == nil, t)
+	assert(eq, t)
+	assert(d.ExactString() == "99.99", t)
+
+	var e currency.Currency
+	assert(e.Scan(int64(100)) == nil, t)
+	assert(e.StringFixed(2) == "100.00", t)
+
+	// Scan's string/[]byte/float64 branches all route through
+	// parse() too - verify a negative value survives intact rather
+	// than being silently recomputed with the wrong sign.
+	var neg currency.Currency
+	assert(neg.Scan("-50.25") == nil, t)
+	assert(neg.ExactString() == "-50.25", t)
+
+	var negFloat currency.Currency
+	assert(negFloat.Scan(float64(-50.25)) == nil, t)
+	assert(negFloat.ExactString() == "-50.25", t)
+}
+
+func Test_JSONNegative(t *testing.T) {
+	var c currency.Currency
+	assert(c.UnmarshalJSON([]byte(`"-50.25"`)) == nil, t)
+	assert(c.ExactString() == "-50.25", t)
+}
+
+func Test_JSONQuoting(t *testing.T) {
+	c, err := currency.NewFromString("42.5")
+	assert(err == nil, t)
+
+	m, err := c.MarshalJSON()
+	assert(err == nil, t)
+	assert(string(m) == `"42.5"`, t)
+
+	currency.SetJSONQuoted(false)
+	defer currency.SetJSONQuoted(true)
+
+	m, err = c.MarshalJSON()
+	assert(err == nil, t)
+	assert(string(m) == "42.5", t)
+}
@@ -0,0 +1,131 @@
+// human.go - Human-readable magnitude suffixes (1.23K, 4.56M, ...)
+//
+// (c) 2017, Sudhi Herle <sudhi@herle.net>
+//
+//
+// Licensing Terms: GPLv2
+//
+// If you need a commercial license for this work, please contact
+// the author.
+//
+// This software does not come with any express or implied
+// warranty; it is provided "as is". No claim  is made to its
+// suitability for any purpose.
+
+package currency
+
+import (
+	"math/big"
+	"strings"
+)
+
+// humanSuffixes are SI decimal magnitude suffixes, ordered from
+// smallest to largest.
+var humanSuffixes = []struct {
+	letter byte
+	exp    int
+}{
+	{'K', 3},
+	{'M', 6},
+	{'B', 9},
+	{'T', 12},
+	{'Q', 15},
+}
+
+// stripHumanSuffix removes a trailing magnitude suffix letter (one of
+// K, M, B, T, Q) from 's', returning the bare numeric text and the
+// suffix's power of ten (0 if 's' carries no suffix).
+func stripHumanSuffix(s string) (string, int) {
+	if len(s) == 0 {
+		return s, 0
+	}
+
+	last := s[len(s)-1]
+	for _, hs := range humanSuffixes {
+		if last == hs.letter {
+			return s[:len(s)-1], hs.exp
+		}
+	}
+	return s, 0
+}
+
+// StringHuman formats 'p' using an SI decimal magnitude suffix
+// (1.23K, 4.56M, 7.89B, 1.23T, ...), with 2 digits after the decimal
+// point. It is equivalent to StringHumanFixed(2).
+func (p *Currency) StringHuman() string {
+	return p.StringHumanFixed(2)
+}
+
+// StringHumanFixed is like StringHuman, but with 'sig' digits after
+// the decimal point in the mantissa. The largest suffix for which the
+// mantissa's integer part is >= 1 is chosen, and the mantissa is
+// rounded using the package's default rounding mode (see
+// SetDefaultRounding). Values smaller than the smallest suffix (1000)
+// fall through to String().
+func (p *Currency) StringHumanFixed(sig int) string {
+	if sig < 0 {
+		sig = 0
+	}
+
+	neg := p.Int.Sign() < 0
+	abs := new(big.Int).Abs(&p.Int)
+
+	for i := len(humanSuffixes) - 1; i >= 0; i-- {
+		hs := humanSuffixes[i]
+		threshold := pow10Big(eExp + hs.exp)
+		if abs.Cmp(threshold) < 0 {
+			continue
+		}
+
+		div := pow10Big(eExp + hs.exp - sig)
+		mantissa := quoRound(abs, div, defaultRounding, neg)
+
+		// Rounding can push the mantissa up to 1000 at this tier (e.g.
+		// 999999.999 rounds to "1000.00K") - bump to the next suffix
+		// so the mantissa stays in [1, 1000). There's no suffix past
+		// Q, so the largest tier is left as-is.
+		if bumped := i + 1; bumped < len(humanSuffixes) {
+			if mantissa.Cmp(pow10Big(sig+3)) >= 0 {
+				hs = humanSuffixes[bumped]
+				div = pow10Big(eExp + hs.exp - sig)
+				mantissa = quoRound(abs, div, defaultRounding, neg)
+			}
+		}
+
+		s := mantissa.String()
+		if pad := sig + 1 - len(s); pad > 0 {
+			s = strings.Repeat("0", pad) + s
+		}
+
+		n := len(s) - sig
+		out := s[:n]
+		if sig > 0 {
+			out = out + "." + s[n:]
+		}
+		if neg {
+			out = "-" + out
+		}
+		return out + string(hs.letter)
+	}
+
+	return p.String()
+}
+
+// ExactString returns 'p' as an exact decimal string, stripping
+// trailing zeroes from the fractional part instead of forcing the
+// full 18 atto-dollar digits that String() produces for unit-less
+// values.
+func (p *Currency) ExactString() string {
+	s := stringify(&p.Int, eExp)
+
+	i := strings.IndexByte(s, '.')
+	if i < 0 {
+		return s
+	}
+
+	frac := strings.TrimRight(s[i+1:], "0")
+	if frac == "" {
+		return s[:i]
+	}
+	return s[:i] + "." + frac
+}
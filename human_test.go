@@ -0,0 +1,82 @@
+package currency_test
+
+import (
+	"testing"
+
+	"currency"
+)
+
+var stringHumanTests = []struct {
+	in  string
+	out string
+}{
+	{"1230", "1.23K"},
+	{"-1230", "-1.23K"},
+	{"1000000", "1.00M"},
+	{"7890000000", "7.89B"},
+	{"1230000000000", "1.23T"},
+}
+
+func Test_StringHuman(t *testing.T) {
+	for _, tc := range stringHumanTests {
+		c, err := currency.NewFromString(tc.in)
+		assert(err == nil, t)
+
+		s := c.StringHuman()
+		t.Logf("in=|%s| => |%s| (want |%s|)\n", tc.in, s, tc.out)
+		assert(s == tc.out, t)
+	}
+}
+
+// Test_StringHuman_BoundaryCrossing guards against StringHumanFixed
+// printing e.g. "1000.00K" when rounding the mantissa pushes it up to
+// 1000 - it must instead renormalize to the next suffix ("1.00M").
+func Test_StringHuman_BoundaryCrossing(t *testing.T) {
+	currency.SetDefaultRounding(currency.ToNearestEven)
+	defer currency.SetDefaultRounding(currency.ToZero)
+
+	c, err := currency.NewFromString("999999.999")
+	assert(err == nil, t)
+
+	s := c.StringHumanFixed(2)
+	t.Logf("got=|%s| (want |1.00M|)\n", s)
+	assert(s == "1.00M", t)
+}
+
+func Test_StringHuman_BelowThousand(t *testing.T) {
+	c, err := currency.NewFromString("999")
+	assert(err == nil, t)
+
+	// No suffix applies below 1000 - falls through to String().
+	assert(c.StringHuman() == c.String(), t)
+}
+
+var humanSuffixParseTests = []struct {
+	in  string
+	out string
+}{
+	{"1.23K", "1230"},
+	{"4.56M", "4560000"},
+	{"7.89B", "7890000000"},
+}
+
+func Test_NewFromString_HumanSuffix(t *testing.T) {
+	for _, tc := range humanSuffixParseTests {
+		c, err := currency.NewFromString(tc.in)
+		assert(err == nil, t)
+
+		s := c.StringFixed(2)
+		t.Logf("in=|%s| => |%s| (want |%s|.00)\n", tc.in, s, tc.out)
+		assert(s == tc.out+".00", t)
+	}
+}
+
+func Test_ExactString(t *testing.T) {
+	c, err := currency.NewFromString("123.45")
+	assert(err == nil, t)
+	assert(c.ExactString() == "123.45", t)
+
+	z, err := currency.NewFromString("100")
+	assert(err == nil, t)
+	assert(z.ExactString() == "100", t)
+}
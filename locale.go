@@ -0,0 +1,96 @@
+// locale.go - Locale-aware grouping for Currency.String
+//
+// (c) 2017, Sudhi Herle <sudhi@herle.net>
+//
+//
+// Licensing Terms: GPLv2
+//
+// If you need a commercial license for this work, please contact
+// the author.
+//
+// This software does not come with any express or implied
+// warranty; it is provided "as is". No claim  is made to its
+// suitability for any purpose.
+
+package currency
+
+import (
+	"strings"
+)
+
+// Locale controls how String/StringLocale group and punctuate the
+// integer and fractional parts of a formatted Currency.
+type Locale struct {
+	ThousandsSep string
+	DecimalSep   string
+}
+
+// Predefined locales covering the two common conventions.
+var (
+	LocaleUS = Locale{ThousandsSep: ",", DecimalSep: "."}
+	LocaleEU = Locale{ThousandsSep: ".", DecimalSep: ","}
+)
+
+// defaultLocale is used by String (and StringLocale's zero value) for
+// Currency values that carry a Unit.
+var defaultLocale = LocaleUS
+
+// SetDefaultLocale changes the package-wide default locale used when
+// formatting Currency values that carry a Unit. It has no effect on
+// unit-less Currency values, which keep their historical, ungrouped
+// String output.
+func SetDefaultLocale(loc Locale) {
+	defaultLocale = loc
+}
+
+// StringLocale formats 'p' at its Unit's native scale (or the full
+// atto-dollar scale if 'p' carries no Unit), grouping the integer
+// part and punctuating it according to 'loc'.
+func (p *Currency) StringLocale(loc Locale) string {
+	if loc == (Locale{}) {
+		loc = defaultLocale
+	}
+
+	oprec := eExp
+	if p.Unit != nil {
+		oprec = int(p.Unit.Scale)
+	}
+
+	r := roundAtPrec(&p.Int, oprec, defaultRounding)
+	return group(stringify(r, oprec), loc)
+}
+
+// group rewrites a "[-]integer[.fraction]" string produced by
+// stringify, inserting 'loc's thousands separator every 3 integer
+// digits and using 'loc's decimal separator in place of '.'.
+func group(s string, loc Locale) string {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+
+	intPart := s
+	fracPart := ""
+	hasFrac := false
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart, hasFrac = s[:i], s[i+1:], true
+	}
+
+	var b strings.Builder
+	n := len(intPart)
+	for i := 0; i < n; i++ {
+		if i > 0 && (n-i)%3 == 0 {
+			b.WriteString(loc.ThousandsSep)
+		}
+		b.WriteByte(intPart[i])
+	}
+
+	out := b.String()
+	if hasFrac {
+		out = out + loc.DecimalSep + fracPart
+	}
+	if neg {
+		out = "-" + out
+	}
+	return out
+}
@@ -0,0 +1,199 @@
+// rounding.go - Rounding modes for Currency formatting and division
+//
+// (c) 2017, Sudhi Herle <sudhi@herle.net>
+//
+//
+// Licensing Terms: GPLv2
+//
+// If you need a commercial license for this work, please contact
+// the author.
+//
+// This software does not come with any express or implied
+// warranty; it is provided "as is". No claim  is made to its
+// suitability for any purpose.
+
+package currency
+
+import (
+	"math/big"
+)
+
+// RoundingMode determines how a Currency value that can't be
+// represented exactly at a given precision is rounded. The modes
+// mirror big.Float's rounding modes.
+type RoundingMode int
+
+const (
+	// ToZero truncates - i.e., discards the remainder. This is the
+	// historical (and default) behavior of String/StringFixed/Div.
+	ToZero RoundingMode = iota
+
+	// AwayFromZero rounds the magnitude up whenever there is a
+	// non-zero remainder.
+	AwayFromZero
+
+	// ToNearestEven rounds to the nearest representable value;
+	// exact halves round to the neighbor with an even last digit
+	// (banker's rounding).
+	ToNearestEven
+
+	// ToNearestAway rounds to the nearest representable value;
+	// exact halves round away from zero.
+	ToNearestAway
+
+	// ToPositiveInf rounds towards positive infinity.
+	ToPositiveInf
+
+	// ToNegativeInf rounds towards negative infinity.
+	ToNegativeInf
+)
+
+var bigOne = big.NewInt(1)
+
+// defaultRounding is used by String, StringFixed, Div and Inv whenever
+// no explicit RoundingMode is given.
+var defaultRounding RoundingMode = ToZero
+
+// SetDefaultRounding changes the package-wide default rounding mode.
+// It affects String, StringFixed, Div and Inv; it has no effect on
+// callers that already specify an explicit RoundingMode (StringFixedRound,
+// DivRound).
+func SetDefaultRounding(mode RoundingMode) {
+	defaultRounding = mode
+}
+
+// roundUp reports whether the truncated quotient whose non-negative
+// magnitude is 'q' and whose division left a non-zero remainder 'r'
+// (0 < r < d) should be incremented by one, given 'mode'. 'neg'
+// indicates the sign of the true, unrounded quotient.
+func roundUp(r, d, q *big.Int, mode RoundingMode, neg bool) bool {
+	cmp := new(big.Int).Lsh(r, 1).Cmp(d) // compare 2r against d
+
+	switch mode {
+	case ToZero:
+		return false
+
+	case AwayFromZero:
+		return true
+
+	case ToPositiveInf:
+		return !neg
+
+	case ToNegativeInf:
+		return neg
+
+	case ToNearestAway:
+		return cmp >= 0
+
+	case ToNearestEven:
+		if cmp != 0 {
+			return cmp > 0
+		}
+		// exact half - round to the neighbor with an even last digit
+		return q.Bit(0) == 1
+
+	default:
+		return false
+	}
+}
+
+// pow10Big returns 10**n as a big.Int.
+func pow10Big(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// quoRound divides the non-negative 'abs' by the non-negative 'div',
+// rounding the quotient to an integer according to 'mode'. 'neg'
+// indicates the sign of the true, unrounded quotient.
+func quoRound(abs, div *big.Int, mode RoundingMode, neg bool) *big.Int {
+	var q, r big.Int
+	q.QuoRem(abs, div, &r)
+
+	if r.Sign() != 0 && roundUp(&r, div, &q, mode, neg) {
+		q.Add(&q, bigOne)
+	}
+	return &q
+}
+
+// roundAtPrec returns a new atto-scaled value equal to 'b' rounded to
+// 'oprec' fractional digits using 'mode'. The result is still
+// expressed in atto units (scale eExp), so it can be handed to
+// stringify() without any further truncation. 'oprec' must already be
+// clamped to [0, eExp].
+func roundAtPrec(b *big.Int, oprec int, mode RoundingMode) *big.Int {
+	shift := eExp - oprec
+	if shift == 0 {
+		return new(big.Int).Set(b)
+	}
+
+	div := pow10Big(shift)
+	neg := b.Sign() < 0
+	abs := new(big.Int).Abs(b)
+
+	q := quoRound(abs, div, mode, neg)
+	q.Mul(q, div)
+	if neg {
+		q.Neg(q)
+	}
+	return q
+}
+
+// StringFixedRound is like StringFixed, but rounds the discarded
+// digits according to 'mode' instead of truncating them. 'oprec' is
+// clamped to [0, eExp].
+func (p *Currency) StringFixedRound(oprec int, mode RoundingMode) string {
+	if oprec > eExp {
+		oprec = eExp
+	} else if oprec < 0 {
+		oprec = 0
+	}
+
+	r := roundAtPrec(&p.Int, oprec, mode)
+	return stringify(r, oprec)
+}
+
+// DivRound divides 'p' by 'x', rounding the quotient according to
+// 'mode' instead of truncating it, and returns 'p'. Both operands are
+// atto-scaled, so the numerator is rescaled by iBigMult before
+// dividing - otherwise the quotient of two atto-scaled values would
+// come out short by a factor of iBigMult.
+func (p *Currency) DivRound(x *Currency, mode RoundingMode) *Currency {
+	neg := (p.Int.Sign() < 0) != (x.Int.Sign() < 0)
+
+	num := new(big.Int).Abs(&p.Int)
+	num.Mul(num, iBigMult)
+	d := new(big.Int).Abs(&x.Int)
+
+	q := quoRound(num, d, mode, neg)
+	if neg {
+		q.Neg(q)
+	}
+
+	p.Int.Set(q)
+	return p
+}
+
+// DivRound returns a/b, rounding the quotient according to 'mode'
+// instead of truncating it.
+func DivRound(a, b *Currency, mode RoundingMode) *Currency {
+	z := &Currency{}
+	z.Int.Set(&a.Int)
+	return z.DivRound(b, mode)
+}
+
+// mulRound returns a*b rescaled back down to atto precision
+// (a*b/iBigMult), rounding the quotient according to 'mode'. 'a' and
+// 'b' are both atto-scaled, so their raw product is scaled by
+// iBigMult^2 and must be divided back down by one factor of iBigMult.
+func mulRound(a, b *big.Int, mode RoundingMode) *big.Int {
+	neg := (a.Sign() < 0) != (b.Sign() < 0)
+
+	prod := new(big.Int).Mul(a, b)
+	prod.Abs(prod)
+
+	q := quoRound(prod, iBigMult, mode, neg)
+	if neg {
+		q.Neg(q)
+	}
+	return q
+}
@@ -0,0 +1,71 @@
+package currency_test
+
+import (
+	"math/big"
+	"testing"
+
+	"currency"
+)
+
+func mkraw(n int64) *currency.Currency {
+	c := currency.New()
+	c.Int = *big.NewInt(n)
+	return c
+}
+
+const attoScale = 1000000000000000000 // 1e18, matches eExp in currency.go
+
+var roundFixedTests = []struct {
+	atto  int64
+	oprec int
+	mode  currency.RoundingMode
+	out   string
+}{
+	{25 * attoScale / 10, 0, currency.ToNearestEven, "2"},
+	{35 * attoScale / 10, 0, currency.ToNearestEven, "4"},
+	{-25 * attoScale / 10, 0, currency.ToNearestEven, "-2"},
+	{-35 * attoScale / 10, 0, currency.ToNearestEven, "-4"},
+	{25 * attoScale / 10, 0, currency.ToNearestAway, "3"},
+	{-25 * attoScale / 10, 0, currency.ToNearestAway, "-3"},
+	{24 * attoScale / 10, 0, currency.ToNearestEven, "2"},
+	{26 * attoScale / 10, 0, currency.ToNearestEven, "3"},
+}
+
+func Test_StringFixedRound(t *testing.T) {
+	for _, tc := range roundFixedTests {
+		c := mkraw(tc.atto)
+
+		s := c.StringFixedRound(tc.oprec, tc.mode)
+		t.Logf("atto=%d, oprec=%d, mode=%d => |%s| (want |%s|)\n", tc.atto, tc.oprec, tc.mode, s, tc.out)
+		assert(s == tc.out, t)
+	}
+}
+
+// divRoundTests exercise DivRound on atto-scaled operands (i.e.
+// a/attoScale and b/attoScale are the "dollar" amounts being divided),
+// so the quotient itself comes back atto-scaled - e.g. 1/3 == 0.333...
+// rather than the raw integer division 1/3 == 0.
+var divRoundTests = []struct {
+	a, b int64
+	mode currency.RoundingMode
+	out  int64
+}{
+	{attoScale * 1, attoScale * 3, currency.ToZero, 333333333333333333},
+	{attoScale * 1, attoScale * 3, currency.AwayFromZero, 333333333333333334},
+	{attoScale * 1, attoScale * 3, currency.ToNearestEven, 333333333333333333},
+	{-attoScale * 1, attoScale * 3, currency.ToNearestEven, -333333333333333333},
+	{attoScale * 5, attoScale * 2, currency.ToZero, 2500000000000000000},
+	{attoScale * 1, attoScale * 6, currency.ToZero, 166666666666666666},
+	{attoScale * 1, attoScale * 6, currency.ToNearestEven, 166666666666666667},
+	{attoScale * 1, attoScale * 6, currency.ToNearestAway, 166666666666666667},
+}
+
+func Test_DivRound(t *testing.T) {
+	for _, tc := range divRoundTests {
+		a, b := mkraw(tc.a), mkraw(tc.b)
+		a.DivRound(b, tc.mode)
+
+		t.Logf("%d/%d mode=%d => %s (want %d)\n", tc.a, tc.b, tc.mode, a.ExactString(), tc.out)
+		assert(a.Int.Cmp(big.NewInt(tc.out)) == 0, t)
+	}
+}
@@ -0,0 +1,64 @@
+// sql.go - database/sql driver.Valuer and sql.Scanner for Currency
+//
+// (c) 2017, Sudhi Herle <sudhi@herle.net>
+//
+//
+// Licensing Terms: GPLv2
+//
+// If you need a commercial license for this work, please contact
+// the author.
+//
+// This software does not come with any express or implied
+// warranty; it is provided "as is". No claim  is made to its
+// suitability for any purpose.
+
+package currency
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Value implements driver.Valuer, storing 'p' as its exact decimal
+// string - compatible with a Postgres NUMERIC(38,18) column, and with
+// SQLite's TEXT affinity.
+func (p Currency) Value() (driver.Value, error) {
+	return p.ExactString(), nil
+}
+
+// Scan implements sql.Scanner, accepting the value forms a driver is
+// likely to hand back for a NUMERIC/TEXT column ([]byte, string) as
+// well as the forms a driver may use for an integral or
+// floating-point column (int64, float64).
+func (p *Currency) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		p.Int = big.Int{}
+		return nil
+
+	case []byte:
+		p.Int.SetInt64(0)
+		return parse(&p.Int, string(v))
+
+	case string:
+		p.Int.SetInt64(0)
+		return parse(&p.Int, v)
+
+	case int64:
+		p.Int.Mul(big.NewInt(v), iBigMult)
+		return nil
+
+	case float64:
+		c, err := NewFromString(strconv.FormatFloat(v, 'f', -1, 64))
+		if err != nil {
+			return err
+		}
+		p.Int = c.Int
+		return nil
+
+	default:
+		return fmt.Errorf("currency: cannot scan %T into Currency", src)
+	}
+}
@@ -0,0 +1,261 @@
+// unit.go - Currency units (ISO 4217 and custom) and the unit registry
+//
+// (c) 2017, Sudhi Herle <sudhi@herle.net>
+//
+//
+// Licensing Terms: GPLv2
+//
+// If you need a commercial license for this work, please contact
+// the author.
+//
+// This software does not come with any express or implied
+// warranty; it is provided "as is". No claim  is made to its
+// suitability for any purpose.
+
+package currency
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Unit describes a unit of account - an ISO 4217 currency or a custom
+// unit such as a cryptocurrency. Scale is the number of fractional
+// digits the unit is conventionally displayed with (e.g. 2 for USD,
+// 0 for JPY, 3 for BHD).
+type Unit struct {
+	Code   string
+	Scale  uint8
+	Symbol string
+}
+
+var (
+	unitsMu sync.RWMutex
+	units   = map[string]*Unit{}
+)
+
+// RegisterUnit adds 'u' to the package-wide unit registry, keyed by
+// its Code (case-insensitively), replacing any previous entry with
+// the same code. Use it to register units that aren't part of ISO
+// 4217, e.g.:
+//
+//	currency.RegisterUnit(&currency.Unit{Code: "BTC", Scale: 8, Symbol: "₿"})
+func RegisterUnit(u *Unit) {
+	unitsMu.Lock()
+	defer unitsMu.Unlock()
+	units[strings.ToUpper(u.Code)] = u
+}
+
+// LookupUnit returns the registered Unit for 'code' (case-insensitive),
+// or nil if no such unit is registered.
+func LookupUnit(code string) *Unit {
+	unitsMu.RLock()
+	defer unitsMu.RUnlock()
+	return units[strings.ToUpper(code)]
+}
+
+// UnitMismatchError is returned by operations that require two
+// Currency values to share a unit (Add, Sub, Cmp, Eq) when they don't.
+type UnitMismatchError struct {
+	A, B string // the mismatched unit codes
+}
+
+func (e *UnitMismatchError) Error() string {
+	return fmt.Sprintf("currency: mismatched units %q and %q", e.A, e.B)
+}
+
+// chooseUnit returns whichever of 'a', 'b' is non-nil, preferring 'a'.
+// Used to propagate a Unit through an arithmetic result when only one
+// of its operands carries one.
+func chooseUnit(a, b *Unit) *Unit {
+	if a != nil {
+		return a
+	}
+	return b
+}
+
+// checkUnit returns a *UnitMismatchError if 'a' and 'b' both carry a
+// unit and those units differ. A nil Unit on either side is treated
+// as "unspecified" and never conflicts - this keeps bare Currency
+// values (the pre-existing, unit-less behavior) usable everywhere.
+func checkUnit(a, b *Currency) error {
+	if a.Unit == nil || b.Unit == nil || a.Unit == b.Unit {
+		return nil
+	}
+	if a.Unit.Code != b.Unit.Code {
+		return &UnitMismatchError{A: a.Unit.Code, B: b.Unit.Code}
+	}
+	return nil
+}
+
+// iso4217 seeds the registry with ISO 4217 currency codes and their
+// minor-unit (decimal-digit) scale. It is not the complete ISO 4217
+// list: of the fund codes only CLF is present, and the precious-metal
+// and other X-codes (e.g. XAU, XDR) are absent. Units with no widely recognized symbol just use their code as the
+// Symbol, same as CHF below. Callers needing a unit that isn't (or is
+// no longer) part of ISO 4217, e.g. a cryptocurrency, can add it with
+// RegisterUnit.
+var iso4217 = []*Unit{
+	{Code: "AED", Scale: 2, Symbol: "AED"},
+	{Code: "AFN", Scale: 2, Symbol: "AFN"},
+	{Code: "ALL", Scale: 2, Symbol: "ALL"},
+	{Code: "AMD", Scale: 2, Symbol: "AMD"},
+	{Code: "ANG", Scale: 2, Symbol: "ANG"},
+	{Code: "AOA", Scale: 2, Symbol: "AOA"},
+	{Code: "ARS", Scale: 2, Symbol: "$"},
+	{Code: "AUD", Scale: 2, Symbol: "$"},
+	{Code: "AWG", Scale: 2, Symbol: "AWG"},
+	{Code: "AZN", Scale: 2, Symbol: "AZN"},
+	{Code: "BAM", Scale: 2, Symbol: "BAM"},
+	{Code: "BBD", Scale: 2, Symbol: "$"},
+	{Code: "BDT", Scale: 2, Symbol: "BDT"},
+	{Code: "BGN", Scale: 2, Symbol: "BGN"},
+	{Code: "BHD", Scale: 3, Symbol: "BD"},
+	{Code: "BIF", Scale: 0, Symbol: "BIF"},
+	{Code: "BMD", Scale: 2, Symbol: "$"},
+	{Code: "BND", Scale: 2, Symbol: "$"},
+	{Code: "BOB", Scale: 2, Symbol: "BOB"},
+	{Code: "BRL", Scale: 2, Symbol: "R$"},
+	{Code: "BSD", Scale: 2, Symbol: "$"},
+	{Code: "BTN", Scale: 2, Symbol: "BTN"},
+	{Code: "BWP", Scale: 2, Symbol: "BWP"},
+	{Code: "BYN", Scale: 2, Symbol: "BYN"},
+	{Code: "BZD", Scale: 2, Symbol: "$"},
+	{Code: "CAD", Scale: 2, Symbol: "$"},
+	{Code: "CDF", Scale: 2, Symbol: "CDF"},
+	{Code: "CHF", Scale: 2, Symbol: "CHF"},
+	{Code: "CLF", Scale: 4, Symbol: "CLF"},
+	{Code: "CLP", Scale: 0, Symbol: "$"},
+	{Code: "CNY", Scale: 2, Symbol: "¥"},
+	{Code: "COP", Scale: 2, Symbol: "$"},
+	{Code: "CRC", Scale: 2, Symbol: "₡"},
+	{Code: "CUP", Scale: 2, Symbol: "$"},
+	{Code: "CVE", Scale: 2, Symbol: "CVE"},
+	{Code: "CZK", Scale: 2, Symbol: "Kč"},
+	{Code: "DJF", Scale: 0, Symbol: "DJF"},
+	{Code: "DKK", Scale: 2, Symbol: "kr"},
+	{Code: "DOP", Scale: 2, Symbol: "$"},
+	{Code: "DZD", Scale: 2, Symbol: "DZD"},
+	{Code: "EGP", Scale: 2, Symbol: "£"},
+	{Code: "ERN", Scale: 2, Symbol: "ERN"},
+	{Code: "ETB", Scale: 2, Symbol: "ETB"},
+	{Code: "EUR", Scale: 2, Symbol: "€"},
+	{Code: "FJD", Scale: 2, Symbol: "$"},
+	{Code: "FKP", Scale: 2, Symbol: "£"},
+	{Code: "GBP", Scale: 2, Symbol: "£"},
+	{Code: "GEL", Scale: 2, Symbol: "GEL"},
+	{Code: "GHS", Scale: 2, Symbol: "GHS"},
+	{Code: "GIP", Scale: 2, Symbol: "£"},
+	{Code: "GMD", Scale: 2, Symbol: "GMD"},
+	{Code: "GNF", Scale: 0, Symbol: "GNF"},
+	{Code: "GTQ", Scale: 2, Symbol: "GTQ"},
+	{Code: "GYD", Scale: 2, Symbol: "$"},
+	{Code: "HKD", Scale: 2, Symbol: "$"},
+	{Code: "HNL", Scale: 2, Symbol: "HNL"},
+	{Code: "HTG", Scale: 2, Symbol: "HTG"},
+	{Code: "HUF", Scale: 2, Symbol: "Ft"},
+	{Code: "IDR", Scale: 2, Symbol: "Rp"},
+	{Code: "ILS", Scale: 2, Symbol: "₪"},
+	{Code: "INR", Scale: 2, Symbol: "₹"},
+	{Code: "IQD", Scale: 3, Symbol: "IQD"},
+	{Code: "IRR", Scale: 2, Symbol: "﷼"},
+	{Code: "ISK", Scale: 0, Symbol: "kr"},
+	{Code: "JMD", Scale: 2, Symbol: "$"},
+	{Code: "JOD", Scale: 3, Symbol: "JD"},
+	{Code: "JPY", Scale: 0, Symbol: "¥"},
+	{Code: "KES", Scale: 2, Symbol: "KES"},
+	{Code: "KGS", Scale: 2, Symbol: "KGS"},
+	{Code: "KHR", Scale: 2, Symbol: "៛"},
+	{Code: "KMF", Scale: 0, Symbol: "KMF"},
+	{Code: "KPW", Scale: 2, Symbol: "₩"},
+	{Code: "KRW", Scale: 0, Symbol: "₩"},
+	{Code: "KWD", Scale: 3, Symbol: "KD"},
+	{Code: "KYD", Scale: 2, Symbol: "$"},
+	{Code: "KZT", Scale: 2, Symbol: "KZT"},
+	{Code: "LAK", Scale: 2, Symbol: "₭"},
+	{Code: "LBP", Scale: 2, Symbol: "£"},
+	{Code: "LKR", Scale: 2, Symbol: "₨"},
+	{Code: "LRD", Scale: 2, Symbol: "$"},
+	{Code: "LSL", Scale: 2, Symbol: "LSL"},
+	{Code: "LYD", Scale: 3, Symbol: "LD"},
+	{Code: "MAD", Scale: 2, Symbol: "MAD"},
+	{Code: "MDL", Scale: 2, Symbol: "MDL"},
+	{Code: "MGA", Scale: 2, Symbol: "MGA"},
+	{Code: "MKD", Scale: 2, Symbol: "MKD"},
+	{Code: "MMK", Scale: 2, Symbol: "MMK"},
+	{Code: "MNT", Scale: 2, Symbol: "₮"},
+	{Code: "MOP", Scale: 2, Symbol: "MOP"},
+	{Code: "MRU", Scale: 2, Symbol: "MRU"},
+	{Code: "MUR", Scale: 2, Symbol: "₨"},
+	{Code: "MVR", Scale: 2, Symbol: "MVR"},
+	{Code: "MWK", Scale: 2, Symbol: "MWK"},
+	{Code: "MXN", Scale: 2, Symbol: "$"},
+	{Code: "MYR", Scale: 2, Symbol: "RM"},
+	{Code: "MZN", Scale: 2, Symbol: "MZN"},
+	{Code: "NAD", Scale: 2, Symbol: "$"},
+	{Code: "NGN", Scale: 2, Symbol: "₦"},
+	{Code: "NIO", Scale: 2, Symbol: "NIO"},
+	{Code: "NOK", Scale: 2, Symbol: "kr"},
+	{Code: "NPR", Scale: 2, Symbol: "₨"},
+	{Code: "NZD", Scale: 2, Symbol: "$"},
+	{Code: "OMR", Scale: 3, Symbol: "﷼"},
+	{Code: "PAB", Scale: 2, Symbol: "PAB"},
+	{Code: "PEN", Scale: 2, Symbol: "PEN"},
+	{Code: "PGK", Scale: 2, Symbol: "PGK"},
+	{Code: "PHP", Scale: 2, Symbol: "₱"},
+	{Code: "PKR", Scale: 2, Symbol: "₨"},
+	{Code: "PLN", Scale: 2, Symbol: "zł"},
+	{Code: "PYG", Scale: 0, Symbol: "₲"},
+	{Code: "QAR", Scale: 2, Symbol: "QAR"},
+	{Code: "RON", Scale: 2, Symbol: "RON"},
+	{Code: "RSD", Scale: 2, Symbol: "RSD"},
+	{Code: "RUB", Scale: 2, Symbol: "₽"},
+	{Code: "RWF", Scale: 0, Symbol: "RWF"},
+	{Code: "SAR", Scale: 2, Symbol: "SAR"},
+	{Code: "SBD", Scale: 2, Symbol: "$"},
+	{Code: "SCR", Scale: 2, Symbol: "SCR"},
+	{Code: "SDG", Scale: 2, Symbol: "SDG"},
+	{Code: "SEK", Scale: 2, Symbol: "kr"},
+	{Code: "SGD", Scale: 2, Symbol: "$"},
+	{Code: "SHP", Scale: 2, Symbol: "£"},
+	{Code: "SLE", Scale: 2, Symbol: "SLE"},
+	{Code: "SOS", Scale: 2, Symbol: "SOS"},
+	{Code: "SRD", Scale: 2, Symbol: "$"},
+	{Code: "SSP", Scale: 2, Symbol: "SSP"},
+	{Code: "STN", Scale: 2, Symbol: "STN"},
+	{Code: "SYP", Scale: 2, Symbol: "£"},
+	{Code: "SZL", Scale: 2, Symbol: "SZL"},
+	{Code: "THB", Scale: 2, Symbol: "฿"},
+	{Code: "TJS", Scale: 2, Symbol: "TJS"},
+	{Code: "TMT", Scale: 2, Symbol: "TMT"},
+	{Code: "TND", Scale: 3, Symbol: "DT"},
+	{Code: "TOP", Scale: 2, Symbol: "TOP"},
+	{Code: "TRY", Scale: 2, Symbol: "₺"},
+	{Code: "TTD", Scale: 2, Symbol: "$"},
+	{Code: "TWD", Scale: 2, Symbol: "$"},
+	{Code: "TZS", Scale: 2, Symbol: "TZS"},
+	{Code: "UAH", Scale: 2, Symbol: "₴"},
+	{Code: "UGX", Scale: 0, Symbol: "UGX"},
+	{Code: "USD", Scale: 2, Symbol: "$"},
+	{Code: "UYU", Scale: 2, Symbol: "$"},
+	{Code: "UZS", Scale: 2, Symbol: "UZS"},
+	{Code: "VES", Scale: 2, Symbol: "VES"},
+	{Code: "VND", Scale: 0, Symbol: "₫"},
+	{Code: "VUV", Scale: 0, Symbol: "VUV"},
+	{Code: "WST", Scale: 2, Symbol: "WST"},
+	{Code: "XAF", Scale: 0, Symbol: "XAF"},
+	{Code: "XCD", Scale: 2, Symbol: "$"},
+	{Code: "XOF", Scale: 0, Symbol: "XOF"},
+	{Code: "XPF", Scale: 0, Symbol: "XPF"},
+	{Code: "YER", Scale: 2, Symbol: "﷼"},
+	{Code: "ZAR", Scale: 2, Symbol: "R"},
+	{Code: "ZMW", Scale: 2, Symbol: "ZMW"},
+	{Code: "ZWL", Scale: 2, Symbol: "ZWL"},
+}
+
+func init() {
+	for _, u := range iso4217 {
+		RegisterUnit(u)
+	}
+}
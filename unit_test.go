@@ -0,0 +1,90 @@
+package currency_test
+
+import (
+	"testing"
+
+	"currency"
+)
+
+func init() {
+	currency.RegisterUnit(&currency.Unit{Code: "BTC", Scale: 8, Symbol: "₿"})
+}
+
+var unitGoldenTests = []struct {
+	in  string
+	out string
+}{
+	{"1234567 JPY", "1,234,567"},
+	{"1234.56 EUR", "1.234,56"},
+	{"1234.567 BHD", "1,234.567"},
+	{"1.23456789 BTC", "1.23456789"},
+}
+
+func Test_UnitGolden(t *testing.T) {
+	currency.SetDefaultLocale(currency.LocaleUS)
+
+	for _, tc := range unitGoldenTests {
+		c, err := currency.NewFromString(tc.in)
+		assert(err == nil, t)
+
+		// EUR is conventionally grouped with '.' and a ',' decimal
+		// separator - exercise the explicit Locale argument for it.
+		var s string
+		if c.Unit != nil && c.Unit.Code == "EUR" {
+			s = c.StringLocale(currency.LocaleEU)
+		} else {
+			s = c.String()
+		}
+
+		t.Logf("in=|%s| => |%s| (want |%s|)\n", tc.in, s, tc.out)
+		assert(s == tc.out, t)
+	}
+}
+
+func Test_NewFromString_UnitPrefixSuffix(t *testing.T) {
+	a, err := currency.NewFromString("USD 12.34")
+	assert(err == nil, t)
+	assert(a.Unit != nil && a.Unit.Code == "USD", t)
+
+	b, err := currency.NewFromString("12.34 USD")
+	assert(err == nil, t)
+	assert(b.Unit != nil && b.Unit.Code == "USD", t)
+
+	eq, err := a.Eq(b)
+	assert(err == nil, t)
+	assert(eq, t)
+}
+
+// Test_UnitRegistryCoverage spot-checks that the registry is seeded
+// from the broad ISO 4217 table, not just a handful of major
+// currencies.
+func Test_UnitRegistryCoverage(t *testing.T) {
+	for code, scale := range map[string]uint8{
+		"KWD": 3, // 3-decimal Gulf dinar
+		"CLF": 4, // 4-decimal Chilean Unidad de Fomento
+		"ISK": 0, // 0-decimal Icelandic krona
+		"PLN": 2, // 2-decimal Polish zloty
+		"GHS": 2, // 2-decimal Ghanaian cedi
+	} {
+		u := currency.LookupUnit(code)
+		if u == nil {
+			t.Fatalf("%s: not registered", code)
+		}
+		assert(u.Scale == scale, t)
+	}
+}
+
+func Test_UnitMismatch(t *testing.T) {
+	usd, err := currency.NewFromString("10 USD")
+	assert(err == nil, t)
+
+	eur, err := currency.NewFromString("10 EUR")
+	assert(err == nil, t)
+
+	_, err = usd.Add(eur)
+	assert(err != nil, t)
+
+	if _, ok := err.(*currency.UnitMismatchError); !ok {
+		t.Fatalf("expected *currency.UnitMismatchError, got %T", err)
+	}
+}